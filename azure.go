@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// azureBackend probes domain as both an Azure storage account and container
+// name (bucket-hunting convention: the same candidate name is tried as
+// both, mirroring how S3/GCS bucket names are guessed). There's no
+// anonymous-vs-signed split here since the container-listing endpoint is
+// either public or it isn't - Azure has no equivalent of a bucket owner's
+// own credentials working against someone else's storage account.
+type azureBackend struct {
+	http      *http.Client
+	listLimit int // if > 0, list up to this many blobs from readable containers
+}
+
+func (b *azureBackend) Name() string { return "azure" }
+
+func (b *azureBackend) Probe(ctx context.Context, domain string) Result {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	result := Result{domain: domain, provider: b.Name()}
+
+	maxResults := ""
+	if b.listLimit > 0 {
+		maxResults = fmt.Sprintf("&maxresults=%d", b.listLimit)
+	}
+
+	url := fmt.Sprintf("https://%s.blob.core.windows.net/%s?restype=container&comp=list%s", domain, domain, maxResults)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	resp, err := b.http.Do(req)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.found = true
+		result.canRead = true
+		if b.listLimit > 0 {
+			result.sampleKeys, result.err = parseAzureListing(resp.Body)
+		}
+	case http.StatusForbidden:
+		result.found = true
+	case http.StatusNotFound:
+	default:
+		result.err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return result
+}
+
+// parseAzureListing decodes a container-listing XML body (EnumerationResults)
+// into SampleKeys. Azure renders Last-Modified as RFC1123; entries that fail
+// to parse just keep a zero time rather than failing the whole listing.
+func parseAzureListing(body io.Reader) ([]SampleKey, error) {
+	var parsed struct {
+		Blobs struct {
+			Blob []struct {
+				Name       string `xml:"Name"`
+				Properties struct {
+					ContentLength int64  `xml:"Content-Length"`
+					LastModified  string `xml:"Last-Modified"`
+				} `xml:"Properties"`
+			} `xml:"Blob"`
+		} `xml:"Blobs"`
+	}
+	if err := xml.NewDecoder(body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	keys := make([]SampleKey, 0, len(parsed.Blobs.Blob))
+	for _, blob := range parsed.Blobs.Blob {
+		sk := SampleKey{Key: blob.Name, Size: blob.Properties.ContentLength}
+		if t, err := time.Parse(time.RFC1123, blob.Properties.LastModified); err == nil {
+			sk.LastModified = t
+		}
+		keys = append(keys, sk)
+	}
+	return keys, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Backend probes a single domain against one cloud storage provider and
+// reports whether a bucket/container by that name exists and is readable.
+type Backend interface {
+	Name() string
+	Probe(ctx context.Context, domain string) Result
+}
+
+// probeTimeout bounds how long a single Probe call may run against one
+// domain. Every backend wraps the ctx it's given with this before making any
+// network calls, so one unresponsive or firewalled host can't wedge a worker
+// indefinitely - important with hundreds of domains flowing through a 20+
+// worker pool.
+const probeTimeout = 15 * time.Second
+
+// newBackends builds the backends selected by a comma-separated -providers
+// value (e.g. "aws,gcp,azure"). Each backend owns whatever clients or
+// credentials it needs, so a worker can fan a single domain out to all of
+// them concurrently. listLimit is forwarded to each backend so it can list
+// sample objects from readable buckets (see the -list flag); 0 disables it.
+func newBackends(ctx context.Context, providers string, probeWriteEnabled bool, listLimit int) ([]Backend, error) {
+	httpClient := &http.Client{}
+
+	var backends []Backend
+	for _, name := range strings.Split(providers, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		switch name {
+		case "aws":
+			signed, anon, err := newS3Clients(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("aws backend: %w", err)
+			}
+			backends = append(backends, &s3Backend{signed: signed, anon: anon, probeWriteEnabled: probeWriteEnabled, listLimit: listLimit})
+		case "gcp":
+			signed, err := newGCSClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("gcp backend: %w", err)
+			}
+			backends = append(backends, &gcsBackend{http: httpClient, signed: signed, listLimit: listLimit})
+		case "azure":
+			backends = append(backends, &azureBackend{http: httpClient, listLimit: listLimit})
+		default:
+			return nil, fmt.Errorf("unknown provider %q (want aws, gcp, or azure)", name)
+		}
+	}
+
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no providers selected")
+	}
+	return backends, nil
+}
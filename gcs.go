@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// newGCSClient builds a signed Cloud Storage client from whatever
+// application-default credentials are available. If none are found it falls
+// back to an unauthenticated client, so missing credentials never fail
+// construction; signed reads through that client are simply denied.
+func newGCSClient(ctx context.Context) (*storage.Client, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return storage.NewClient(ctx, option.WithoutAuthentication())
+	}
+	return client, nil
+}
+
+// gcsBackend probes domain as a Google Cloud Storage bucket name: an
+// anonymous GET against the XML API classifies existence vs. access, and a
+// signed client (see newGCSClient) is tried if anonymous access is denied.
+type gcsBackend struct {
+	http      *http.Client
+	signed    *storage.Client
+	listLimit int // if > 0, list up to this many objects from readable buckets
+}
+
+func (b *gcsBackend) Name() string { return "gcp" }
+
+func (b *gcsBackend) Probe(ctx context.Context, domain string) Result {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	result := Result{domain: domain, provider: b.Name()}
+
+	maxKeys := 1
+	if b.listLimit > 0 {
+		maxKeys = b.listLimit
+	}
+
+	url := fmt.Sprintf("https://storage.googleapis.com/%s?max-keys=%d", domain, maxKeys)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	resp, err := b.http.Do(req)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.found = true
+		result.canRead = true
+		if b.listLimit > 0 {
+			result.sampleKeys, result.err = parseXMLListing(resp.Body)
+		}
+		return result
+	case http.StatusForbidden:
+		result.found = true
+	case http.StatusNotFound:
+		return result
+	default:
+		result.err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return result
+	}
+
+	// Exists but anonymous listing was denied; see if our own credentials
+	// can read it.
+	if _, err := b.signed.Bucket(domain).Objects(ctx, &storage.Query{}).Next(); err == nil || err == iterator.Done {
+		result.authRead = true
+		if b.listLimit > 0 {
+			result.sampleKeys = listGCSSampleKeys(ctx, b.signed, domain, b.listLimit)
+		}
+	}
+	return result
+}
+
+// parseXMLListing decodes an XML API ListBucketResult body (GCS's XML API
+// mirrors S3's listing schema) into SampleKeys.
+func parseXMLListing(body io.Reader) ([]SampleKey, error) {
+	var parsed struct {
+		Contents []struct {
+			Key          string    `xml:"Key"`
+			Size         int64     `xml:"Size"`
+			LastModified time.Time `xml:"LastModified"`
+		} `xml:"Contents"`
+	}
+	if err := xml.NewDecoder(body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	keys := make([]SampleKey, 0, len(parsed.Contents))
+	for _, c := range parsed.Contents {
+		keys = append(keys, SampleKey{Key: c.Key, Size: c.Size, LastModified: c.LastModified})
+	}
+	return keys, nil
+}
+
+// listGCSSampleKeys lists up to limit objects using signed credentials, for
+// buckets where only authRead succeeded.
+func listGCSSampleKeys(ctx context.Context, client *storage.Client, domain string, limit int) []SampleKey {
+	it := client.Bucket(domain).Objects(ctx, &storage.Query{})
+	keys := make([]SampleKey, 0, limit)
+	for len(keys) < limit {
+		attrs, err := it.Next()
+		if err != nil {
+			break
+		}
+		keys = append(keys, SampleKey{Key: attrs.Name, Size: attrs.Size, LastModified: attrs.Updated})
+	}
+	return keys
+}
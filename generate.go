@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultSuffixes are used as generation words when the caller doesn't
+// supply a wordlist.
+var defaultSuffixes = []string{
+	"backup", "assets", "static", "logs", "dev", "staging", "prod", "internal", "dl", "media",
+}
+
+// loadWordlist reads one word per line from path, skipping blank lines. The
+// caller reads it once and shares the resulting slice across every
+// permutation rather than re-reading the file per candidate.
+func loadWordlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// generateCandidates derives plausible bucket names from root and streams
+// them into jobs, de-duplicating as it goes rather than materializing the
+// full candidate list in memory. It returns early if done is closed.
+func generateCandidates(root string, words []string, jobs chan<- string, done <-chan struct{}) {
+	seen := make(map[string]struct{})
+	send := func(candidate string) bool {
+		if _, ok := seen[candidate]; ok {
+			return true
+		}
+		seen[candidate] = struct{}{}
+		select {
+		case jobs <- candidate:
+			return true
+		case <-done:
+			return false
+		}
+	}
+
+	withoutTLD := root
+	if i := strings.LastIndex(root, "."); i != -1 {
+		withoutTLD = root[:i]
+	}
+
+	if !send(root) {
+		return
+	}
+	if withoutTLD != root && !send(withoutTLD) {
+		return
+	}
+
+	for _, word := range words {
+		candidates := [...]string{
+			fmt.Sprintf("%s-%s", withoutTLD, word),
+			fmt.Sprintf("%s-%s", word, withoutTLD),
+			fmt.Sprintf("%s.%s", withoutTLD, word),
+			fmt.Sprintf("%s.%s", word, withoutTLD),
+		}
+		for _, candidate := range candidates {
+			if !send(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// expectedCandidateCount returns an upper bound on how many candidates
+// generateCandidates will produce for root and words - it doesn't account
+// for de-duplication, so it's only meant for a rough ETA, not an exact count.
+func expectedCandidateCount(root string, words []string) int {
+	count := 1 // root
+	if i := strings.LastIndex(root, "."); i != -1 {
+		count++ // withoutTLD, distinct from root whenever it has a TLD to strip
+	}
+	return count + 4*len(words)
+}
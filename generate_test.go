@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestGenerateCandidatesDedup(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  string
+		words []string
+		want  []string
+	}{
+		{
+			name:  "suffix collides with root itself",
+			root:  "acme.com",
+			words: []string{"com"},
+			// withoutTLD+"."+word reproduces the root ("acme.com"), so it's
+			// deduped; the other three permutations of "com" are distinct.
+			want: []string{"acme.com", "acme", "acme-com", "com-acme", "com.acme"},
+		},
+		{
+			name:  "no TLD to strip",
+			root:  "localhost",
+			words: []string{"backup"},
+			// withoutTLD == root, so only one of the two is sent.
+			want: []string{"localhost", "localhost-backup", "backup-localhost", "localhost.backup", "backup.localhost"},
+		},
+		{
+			name:  "repeated word dedups across iterations",
+			root:  "acme.com",
+			words: []string{"backup", "backup"},
+			want:  []string{"acme.com", "acme", "acme-backup", "backup-acme", "acme.backup", "backup.acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := make(chan string, 100)
+			generateCandidates(tt.root, tt.words, jobs, nil)
+			close(jobs)
+
+			var got []string
+			for c := range jobs {
+				got = append(got, c)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d candidates %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i, want := range tt.want {
+				if got[i] != want {
+					t.Errorf("candidate %d = %q, want %q (all: %v)", i, got[i], want, got)
+				}
+			}
+		})
+	}
+}
@@ -2,14 +2,15 @@ package main
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -18,16 +19,29 @@ import (
 var (
 	styleFound = lipgloss.NewStyle().Foreground(lipgloss.Color("10")) // Green
 	styleWrite = lipgloss.NewStyle().Foreground(lipgloss.Color("11")) // Yellow
-	styleAWS   = lipgloss.NewStyle().Foreground(lipgloss.Color("14")) // Cyan
+	styleCreds = lipgloss.NewStyle().Foreground(lipgloss.Color("14")) // Cyan
 	styleDim   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))  // Gray
 )
 
 type Result struct {
-	domain   string
-	found    bool
-	canRead  bool
-	canWrite bool
-	awsRead  bool
+	domain     string
+	provider   string // "aws", "gcp", or "azure"
+	found      bool
+	canRead    bool
+	canWrite   bool
+	authRead   bool        // readable with our own credentials, anonymous access denied
+	probeKey   string      // object key used for the write probe, if one ran
+	probeErr   error       // non-AccessDenied failure from the write probe, if any
+	sampleKeys []SampleKey // populated when -list > 0 and the bucket is readable
+	err        error       // unexpected error encountered while probing, if any
+}
+
+// SampleKey describes one object found while listing a readable bucket
+// (see the -list flag).
+type SampleKey struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
 }
 
 type model struct {
@@ -38,6 +52,12 @@ type model struct {
 	err          error
 	processing   bool
 	debug        string
+
+	totalJobs     int64 // 0 if unknown, e.g. domains piped in rather than generated
+	completed     int64
+	rate          float64
+	elapsed       time.Duration
+	providerRates map[string]float64
 }
 
 func (m model) Init() tea.Cmd {
@@ -53,22 +73,37 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 	case Result:
-		m.stats.increment(msg.found, msg.canRead, msg.canWrite, msg.awsRead)
-		if msg.found && (msg.canRead || msg.awsRead) {
-			badges := ""
+		m.stats.increment(msg.provider, msg.found, msg.canRead, msg.canWrite, msg.authRead)
+		if msg.found && (msg.canRead || msg.authRead) {
+			badges := " " + styleDim.Render("["+strings.ToUpper(msg.provider)+"]")
 			if msg.canRead {
 				badges += " " + styleFound.Render("READ")
 			}
 			if msg.canWrite {
 				badges += " " + styleWrite.Render("WRITE")
 			}
-			if msg.awsRead {
-				badges += " " + styleAWS.Render("AWS")
+			if msg.authRead {
+				badges += " " + styleCreds.Render("CREDS")
 			}
 			m.foundBuckets = append(m.foundBuckets, fmt.Sprintf("%s%s", msg.domain, badges))
 		}
+		if msg.probeKey != "" {
+			if msg.probeErr != nil {
+				m.debug = fmt.Sprintf("Debug: write probe %s/%s failed: %v", msg.domain, msg.probeKey, msg.probeErr)
+			} else if msg.canWrite {
+				m.debug = fmt.Sprintf("Debug: write probe wrote+cleaned %s/%s", msg.domain, msg.probeKey)
+			} else {
+				m.debug = fmt.Sprintf("Debug: write probe %s/%s denied", msg.domain, msg.probeKey)
+			}
+		}
 		m.testing = msg.domain
 		return m, nil
+	case tickMsg:
+		m.completed = msg.completed
+		m.rate = msg.rate
+		m.elapsed = msg.elapsed
+		m.providerRates = msg.providerRates
+		return m, nil
 	case string: // debug message
 		m.debug = msg
 		return m, nil
@@ -99,6 +134,11 @@ func (m model) View() string {
 		s.WriteString("\n" + styleDim.Render(fmt.Sprintf("Testing: %s", m.testing)))
 	}
 
+	// Throughput panel
+	if m.processing && m.completed > 0 {
+		s.WriteString("\n" + styleDim.Render(m.throughputLine()))
+	}
+
 	// Debug info
 	if m.debug != "" {
 		s.WriteString("\n" + styleDim.Render(m.debug))
@@ -106,74 +146,115 @@ func (m model) View() string {
 
 	// Summary when done
 	if m.done {
-		s.WriteString(fmt.Sprintf("\nSummary: %d tested, %d found (%d readable, %d writable, %d aws), %d not found\n",
-			m.stats.total, m.stats.found, m.stats.withRead, m.stats.withWrite, m.stats.withAwsRead, m.stats.notFound))
+		s.WriteString(fmt.Sprintf("\nSummary: %d tested, %d found (%d readable, %d writable, %d creds-only), %d not found\n",
+			m.stats.total, m.stats.found, m.stats.withRead, m.stats.withWrite, m.stats.withAuthRead, m.stats.notFound))
+
+		if len(m.stats.byProvider) > 1 {
+			providers := make([]string, 0, len(m.stats.byProvider))
+			for name := range m.stats.byProvider {
+				providers = append(providers, name)
+			}
+			sort.Strings(providers)
+			for _, name := range providers {
+				pc := m.stats.byProvider[name]
+				s.WriteString(fmt.Sprintf("  %-5s %d tested, %d found (%d readable, %d writable, %d creds-only), %d not found\n",
+					name, pc.total, pc.found, pc.withRead, pc.withWrite, pc.withAuthRead, pc.notFound))
+			}
+		}
 	}
 
 	return s.String()
 }
 
-type Stats struct {
-	total       int
-	found       int
-	notFound    int
-	withRead    int
-	withWrite   int
-	withAwsRead int
-	mu          sync.Mutex
+// throughputLine renders the one-line rate/elapsed/ETA panel, e.g.
+// "1.2k/s · 42s elapsed · ETA 3m12s (aws 900/s, gcp 300/s)".
+func (m model) throughputLine() string {
+	line := fmt.Sprintf("%s · %s elapsed", formatRate(m.rate), formatDuration(m.elapsed))
+
+	if m.totalJobs > 0 && m.rate > 0 {
+		if remaining := float64(m.totalJobs) - float64(m.completed); remaining > 0 {
+			eta := time.Duration(remaining/m.rate) * time.Second
+			line += " · ETA " + formatDuration(eta)
+		}
+	}
+
+	if len(m.providerRates) > 1 {
+		providers := make([]string, 0, len(m.providerRates))
+		for name := range m.providerRates {
+			providers = append(providers, name)
+		}
+		sort.Strings(providers)
+
+		parts := make([]string, 0, len(providers))
+		for _, name := range providers {
+			parts = append(parts, fmt.Sprintf("%s %s", name, formatRate(m.providerRates[name])))
+		}
+		line += " (" + strings.Join(parts, ", ") + ")"
+	}
+
+	return line
 }
 
-func (s *Stats) increment(found bool, canRead, canWrite, awsRead bool) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// providerCounts holds the same tallies as Stats, scoped to one provider.
+type providerCounts struct {
+	total        int
+	found        int
+	notFound     int
+	withRead     int
+	withWrite    int
+	withAuthRead int
+}
 
-	s.total++
+func (c *providerCounts) increment(found bool, canRead, canWrite, authRead bool) {
+	c.total++
 	if !found {
-		s.notFound++
+		c.notFound++
 		return
 	}
-	s.found++
+	c.found++
 	if canRead {
-		s.withRead++
+		c.withRead++
 	}
 	if canWrite {
-		s.withWrite++
+		c.withWrite++
 	}
-	if awsRead {
-		s.withAwsRead++
+	if authRead {
+		c.withAuthRead++
 	}
 }
 
-func analyzeBucket(domain string) Result {
-	result := Result{
-		domain: domain,
-	}
+type Stats struct {
+	providerCounts
+	byProvider map[string]*providerCounts
+	mu         sync.Mutex
+}
 
-	cmd := exec.Command("aws", "s3", "ls", "s3://"+domain)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	err := cmd.Run()
+func (s *Stats) increment(provider string, found bool, canRead, canWrite, authRead bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if err == nil {
-		result.found = true
-		result.canRead = true
-		result.awsRead = true
-		return result
-	}
+	s.providerCounts.increment(found, canRead, canWrite, authRead)
 
-	if strings.Contains(stderr.String(), "NoSuchBucket") {
-		return result
+	if s.byProvider == nil {
+		s.byProvider = make(map[string]*providerCounts)
 	}
-
-	if strings.Contains(stderr.String(), "AccessDenied") || strings.Contains(stderr.String(), "AllAccessDisabled") {
-		result.found = true
+	pc := s.byProvider[provider]
+	if pc == nil {
+		pc = &providerCounts{}
+		s.byProvider[provider] = pc
 	}
-	return result
+	pc.increment(found, canRead, canWrite, authRead)
 }
 
 func main() {
-	quietMode := flag.Bool("q", false, "Quiet mode - only output CSV format: domain,read,write,aws")
+	quietMode := flag.Bool("q", false, "Quiet mode - only output CSV format: domain,provider,read,write,authRead")
 	workers := flag.Int("w", 20, "Number of concurrent workers")
+	probeWriteEnabled := flag.Bool("write", false, "Probe for write access with a harmless PutObject under .buckhunt-probe/ (cleaned up immediately)")
+	providersFlag := flag.String("providers", "aws", "Comma-separated list of providers to check: aws, gcp, azure")
+	genRoot := flag.String("gen", "", "Generate candidate bucket names from a root domain (e.g. -gen acme.com) instead of reading stdin")
+	wordlistPath := flag.String("wordlist", "", "Wordlist file for -gen permutations, one word per line (defaults to common suffixes)")
+	outputFormat := flag.String("o", "", "Structured output format: json (array) or jsonl (one object per line); overrides -q")
+	listLimit := flag.Int("list", 0, "List up to N objects from each readable bucket into sample_keys (used by -o json/jsonl)")
 	flag.Parse()
 
 	if *workers < 1 {
@@ -182,12 +263,46 @@ func main() {
 		*workers = 100
 	}
 
-	stat, _ := os.Stdin.Stat()
-	isPipe := (stat.Mode() & os.ModeCharDevice) == 0
+	if *outputFormat != "" && *outputFormat != "json" && *outputFormat != "jsonl" {
+		fmt.Fprintf(os.Stderr, "Error: -o must be \"json\" or \"jsonl\"\n")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	backends, err := newBackends(ctx, *providersFlag, *probeWriteEnabled, *listLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up providers: %v\n", err)
+		os.Exit(1)
+	}
+
+	useGenerator := *genRoot != ""
+	genWords := defaultSuffixes
+	if useGenerator && *wordlistPath != "" {
+		genWords, err = loadWordlist(*wordlistPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading wordlist: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	isPipe := useGenerator
+	if !useGenerator {
+		stat, _ := os.Stdin.Stat()
+		isPipe = (stat.Mode() & os.ModeCharDevice) == 0
+	}
+
+	nonInteractive := *quietMode || *outputFormat != ""
 
 	if isPipe {
-		if *quietMode {
-			// Process in quiet mode (CSV output)
+		if nonInteractive {
+			// Process without the TUI, streaming results out as CSV or
+			// structured JSON/JSONL (see -o).
+			format := "csv"
+			if *outputFormat != "" {
+				format = *outputFormat
+			}
+			rw := newResultWriter(os.Stdout, format)
+
 			jobs := make(chan string, *workers)
 			results := make(chan Result, *workers)
 			var wg sync.WaitGroup
@@ -199,7 +314,9 @@ func main() {
 				go func() {
 					defer wg.Done()
 					for domain := range jobs {
-						results <- analyzeBucket(domain)
+						for _, backend := range backends {
+							results <- backend.Probe(ctx, domain)
+						}
 					}
 				}()
 			}
@@ -210,8 +327,13 @@ func main() {
 				close(results)
 			}()
 
-			// Read domains
+			// Feed domains: either generated candidates or one per stdin line
 			go func() {
+				defer close(jobs)
+				if useGenerator {
+					generateCandidates(*genRoot, genWords, jobs, nil)
+					return
+				}
 				scanner := bufio.NewScanner(os.Stdin)
 				for scanner.Scan() {
 					domain := strings.TrimSpace(scanner.Text())
@@ -219,27 +341,56 @@ func main() {
 						jobs <- domain
 					}
 				}
-				close(jobs)
 			}()
 
-			// Print CSV results
+			// Stream out results as they arrive
 			for result := range results {
-				stats.increment(result.found, result.canRead, result.canWrite, result.awsRead)
-				if result.found && (result.canRead || result.awsRead) {
-					fmt.Printf("%s,%v,%v,%v\n", result.domain, result.canRead, result.canWrite, result.awsRead)
+				stats.increment(result.provider, result.found, result.canRead, result.canWrite, result.authRead)
+				if result.probeErr != nil {
+					fmt.Fprintf(os.Stderr, "Debug: write probe %s/%s failed: %v\n", result.domain, result.probeKey, result.probeErr)
 				}
+				rw.write(result)
 			}
+			rw.close()
 			return
 		}
 
 		// Interactive mode with TUI
-		p := tea.NewProgram(model{processing: true})
+		var totalJobs int64
+		if useGenerator {
+			totalJobs = int64(expectedCandidateCount(*genRoot, genWords) * len(backends))
+		}
+		p := tea.NewProgram(model{processing: true, totalJobs: totalJobs})
 
 		jobs := make(chan string, *workers*2) // Increase buffer size
 		results := make(chan Result, *workers*2)
 		done := make(chan struct{})
 		var wg sync.WaitGroup
 		var jobCount atomic.Int32
+		tracker := newThroughputTracker()
+
+		// huntCtx is cancelled as soon as the user quits so in-flight AWS
+		// calls abort quickly; write-probe cleanup deletes always run
+		// against context.Background() instead, so they still happen.
+		huntCtx, cancelHunt := context.WithCancel(ctx)
+		go func() {
+			<-done
+			cancelHunt()
+		}()
+
+		// Send a throughput reading to the UI twice a second.
+		go func() {
+			ticker := time.NewTicker(500 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					p.Send(tracker.sample(time.Now()))
+				case <-done:
+					return
+				}
+			}
+		}()
 
 		// Start workers
 		for i := 0; i < *workers; i++ {
@@ -247,16 +398,19 @@ func main() {
 			go func(id int) {
 				defer wg.Done()
 				for domain := range jobs {
-					select {
-					case <-done:
-						return
-					default:
-						result := analyzeBucket(domain)
+					for _, backend := range backends {
 						select {
-						case results <- result:
-							jobCount.Add(1)
 						case <-done:
 							return
+						default:
+							result := backend.Probe(huntCtx, domain)
+							select {
+							case results <- result:
+								jobCount.Add(1)
+								tracker.record(result.provider)
+							case <-done:
+								return
+							}
 						}
 					}
 				}
@@ -286,13 +440,19 @@ func main() {
 			close(done)
 		}()
 
-		// Read domains
+		// Feed domains: either generated candidates or one per stdin line
 		go func() {
 			defer func() {
 				close(jobs)
 				p.Send("Debug: Jobs channel closed")
 			}()
 
+			if useGenerator {
+				generateCandidates(*genRoot, genWords, jobs, done)
+				p.Send("Debug: Finished generating candidates")
+				return
+			}
+
 			scanner := bufio.NewScanner(os.Stdin)
 			count := 0
 			skipped := 0
@@ -339,21 +499,47 @@ func main() {
 		fmt.Println("Usage:")
 		fmt.Println("  Single domain:  buckhunt [-q] <domain>")
 		fmt.Println("  Multiple domains via stdin:  cat domains.txt | buckhunt [-q]")
+		fmt.Println("  Generated candidates:  buckhunt -gen acme.com [-wordlist env.txt] [-q]")
+		fmt.Println("  Structured output:  buckhunt -o jsonl -list 10 -gen acme.com")
 		os.Exit(1)
 	}
 
 	// Handle single domain case
-	result := analyzeBucket(args[0])
-	if *quietMode {
-		fmt.Printf("%s,%v,%v,%v\n", result.domain, result.canRead, result.canWrite, result.awsRead)
-	} else {
-		if result.found {
-			fmt.Printf("Found bucket %s:\n", result.domain)
-			fmt.Printf("  Read:  %v\n", result.canRead)
-			fmt.Printf("  Write: %v\n", result.canWrite)
-			fmt.Printf("  AWS:   %v\n", result.awsRead)
+	if *outputFormat != "" {
+		rw := newResultWriter(os.Stdout, *outputFormat)
+		for _, backend := range backends {
+			result := backend.Probe(ctx, args[0])
+			if result.probeErr != nil {
+				fmt.Fprintf(os.Stderr, "Debug: write probe %s/%s failed: %v\n", result.domain, result.probeKey, result.probeErr)
+			}
+			rw.write(result)
+		}
+		rw.close()
+		return
+	}
+
+	for _, backend := range backends {
+		result := backend.Probe(ctx, args[0])
+		if result.probeErr != nil {
+			fmt.Fprintf(os.Stderr, "Debug: write probe %s/%s failed: %v\n", result.domain, result.probeKey, result.probeErr)
+		}
+		if *quietMode {
+			fmt.Printf("%s,%s,%v,%v,%v\n", result.domain, result.provider, result.canRead, result.canWrite, result.authRead)
 		} else {
-			fmt.Printf("Bucket %s not found\n", result.domain)
+			if result.found {
+				fmt.Printf("Found bucket %s (%s):\n", result.domain, result.provider)
+				fmt.Printf("  Read:  %v\n", result.canRead)
+				fmt.Printf("  Write: %v\n", result.canWrite)
+				fmt.Printf("  Creds: %v\n", result.authRead)
+				if result.probeKey != "" {
+					fmt.Printf("  Probe: %s\n", result.probeKey)
+				}
+				for _, sk := range result.sampleKeys {
+					fmt.Printf("  Object: %s (%d bytes, modified %s)\n", sk.Key, sk.Size, sk.LastModified.Format(time.RFC3339))
+				}
+			} else {
+				fmt.Printf("Bucket %s not found (%s)\n", result.domain, result.provider)
+			}
 		}
 	}
 }
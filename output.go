@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// jsonResult is Result's JSON-serializable shape for -o json/jsonl. Result
+// keeps its fields unexported since only Probe/analyzeBucket need them, so
+// this is a deliberate boundary type rather than something to fold into it.
+type jsonResult struct {
+	Domain     string          `json:"domain"`
+	Provider   string          `json:"provider"`
+	Found      bool            `json:"found"`
+	Read       bool            `json:"read"`
+	Write      bool            `json:"write"`
+	AuthRead   bool            `json:"auth_read"`
+	Error      string          `json:"error,omitempty"`
+	SampleKeys []jsonSampleKey `json:"sample_keys,omitempty"`
+}
+
+type jsonSampleKey struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+func toJSONResult(r Result) jsonResult {
+	jr := jsonResult{
+		Domain:   r.domain,
+		Provider: r.provider,
+		Found:    r.found,
+		Read:     r.canRead,
+		Write:    r.canWrite,
+		AuthRead: r.authRead,
+	}
+	if r.err != nil {
+		jr.Error = r.err.Error()
+	}
+	for _, sk := range r.sampleKeys {
+		jr.SampleKeys = append(jr.SampleKeys, jsonSampleKey{Key: sk.Key, Size: sk.Size, LastModified: sk.LastModified})
+	}
+	return jr
+}
+
+// resultWriter emits probe results in one of buckhunt's streaming output
+// formats ("csv", "json", or "jsonl"). It's meant to be driven from a single
+// goroutine - the results-channel consumer in main - so callers never need
+// to synchronize writes across workers; each write is flushed immediately
+// so output stays bounded and visible even on very large runs.
+type resultWriter struct {
+	format string
+	w      *bufio.Writer
+	enc    *json.Encoder
+	first  bool
+}
+
+func newResultWriter(w io.Writer, format string) *resultWriter {
+	bw := bufio.NewWriter(w)
+	rw := &resultWriter{format: format, w: bw, first: true}
+	if format == "json" || format == "jsonl" {
+		rw.enc = json.NewEncoder(bw)
+	}
+	if format == "json" {
+		bw.WriteString("[\n")
+	}
+	return rw
+}
+
+func (rw *resultWriter) write(result Result) {
+	defer rw.w.Flush()
+
+	switch rw.format {
+	case "json":
+		if !rw.first {
+			rw.w.WriteString(",\n")
+		}
+		rw.first = false
+		rw.enc.Encode(toJSONResult(result))
+	case "jsonl":
+		rw.enc.Encode(toJSONResult(result))
+	default: // csv
+		if result.found && (result.canRead || result.authRead) {
+			fmt.Fprintf(rw.w, "%s,%s,%v,%v,%v\n", result.domain, result.provider, result.canRead, result.canWrite, result.authRead)
+		}
+	}
+}
+
+// close finalizes the stream: just the closing bracket for json, a no-op
+// otherwise since csv/jsonl have nothing left to write.
+func (rw *resultWriter) close() {
+	if rw.format == "json" {
+		rw.w.WriteString("]\n")
+	}
+	rw.w.Flush()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// probePrefix namespaces everything buckhunt writes so it's trivially
+// greppable and never collides with real keys in the bucket.
+const probePrefix = ".buckhunt-probe/"
+
+// randomProbeKey returns a unique object key under probePrefix so concurrent
+// probes against the same bucket never collide with each other.
+func randomProbeKey() (string, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return probePrefix + hex.EncodeToString(buf[:]) + ".txt", nil
+}
+
+// probeWrite attempts a harmless PutObject against domain to test for write
+// access, then immediately deletes what it wrote. The delete is issued
+// against context.Background() rather than ctx so cleanup still happens if
+// ctx is cancelled (e.g. the user quit mid-scan) before the delete fires.
+func probeWrite(ctx context.Context, client *s3.Client, domain string) (wrote bool, key string, debugErr error) {
+	key, err := randomProbeKey()
+	if err != nil {
+		return false, "", fmt.Errorf("generating probe key: %w", err)
+	}
+
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(domain),
+		Key:    aws.String(key),
+		Body:   strings.NewReader("buckhunt write probe - safe to delete\n"),
+	})
+	if err != nil {
+		if isAccessDenied(err) {
+			return false, key, nil
+		}
+		if ctx.Err() != nil {
+			// ctx was cancelled while the PutObject was in flight - S3 may
+			// have accepted the write before we observed the cancellation,
+			// so clean up defensively against Background. Deleting a key
+			// that was never written is a harmless no-op.
+			client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+				Bucket: aws.String(domain),
+				Key:    aws.String(key),
+			})
+		}
+		return false, key, err
+	}
+
+	if _, err := client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(domain),
+		Key:    aws.String(key),
+	}); err != nil {
+		return true, key, fmt.Errorf("cleanup delete of %s failed: %w", key, err)
+	}
+	return true, key, nil
+}
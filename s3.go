@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithy "github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// newS3Clients builds the two clients analyzeBucket needs: one that signs
+// requests with whatever credentials are in the environment, and one that
+// forces anonymous requests (the SDK equivalent of --no-sign-request).
+func newS3Clients(ctx context.Context) (signed *s3.Client, anon *s3.Client, err error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signed = s3.NewFromConfig(cfg)
+	anon = s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.Credentials = aws.AnonymousCredentials{}
+	})
+	return signed, anon, nil
+}
+
+// isNoSuchBucket reports whether err indicates the bucket does not exist.
+func isNoSuchBucket(err error) bool {
+	var nsb *types.NoSuchBucket
+	if errors.As(err, &nsb) {
+		return true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucket" {
+		return true
+	}
+
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == 404 {
+		return true
+	}
+	return false
+}
+
+// isAccessDenied reports whether err indicates the bucket exists but the
+// caller isn't allowed to read it.
+func isAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "AccessDenied", "AllAccessDisabled", "Forbidden":
+			return true
+		}
+	}
+
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == 403 {
+		return true
+	}
+	return false
+}
+
+// canList does a cheap ListObjectsV2 (MaxKeys: 1) to confirm a bucket is
+// actually readable rather than just present.
+func canList(ctx context.Context, client *s3.Client, domain string) bool {
+	_, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(domain),
+		MaxKeys: aws.Int32(1),
+	})
+	return err == nil
+}
+
+// analyzeBucket probes domain as an S3 bucket name. It first checks
+// anonymously (classifying existence vs. access) and, if the bucket exists
+// but anonymous access is denied, retries with signed credentials so
+// authRead still means "readable with credentials but not anonymously". When
+// probeWriteEnabled is set and the bucket is found, it also attempts a
+// harmless write probe (see probeWrite).
+func analyzeBucket(ctx context.Context, signed, anon *s3.Client, domain string, probeWriteEnabled bool) Result {
+	result := Result{domain: domain}
+
+	_, err := anon.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(domain)})
+	switch {
+	case err == nil:
+		result.found = true
+		result.canRead = canList(ctx, anon, domain)
+	case isNoSuchBucket(err):
+		return result
+	case isAccessDenied(err):
+		result.found = true
+	default:
+		result.err = err
+		return result
+	}
+
+	if !result.canRead {
+		// Exists but anonymous access was denied (or wasn't checked); see if
+		// our own credentials can read it.
+		if _, err := signed.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(domain)}); err == nil {
+			result.authRead = canList(ctx, signed, domain)
+		}
+	}
+
+	if probeWriteEnabled {
+		client := anon
+		if !result.canRead {
+			client = signed
+		}
+		wrote, key, err := probeWrite(ctx, client, domain)
+		result.canWrite = wrote
+		result.probeKey = key
+		result.probeErr = err
+	}
+
+	return result
+}
+
+// listSampleKeys lists up to limit objects from domain for the -list flag.
+// It's only ever called once a bucket is already known to be readable, so a
+// listing failure here is unexpected and surfaced via Result.err rather than
+// silently ignored.
+func listSampleKeys(ctx context.Context, client *s3.Client, domain string, limit int) ([]SampleKey, error) {
+	resp, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(domain),
+		MaxKeys: aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]SampleKey, 0, len(resp.Contents))
+	for _, obj := range resp.Contents {
+		sk := SampleKey{}
+		if obj.Key != nil {
+			sk.Key = *obj.Key
+		}
+		if obj.Size != nil {
+			sk.Size = *obj.Size
+		}
+		if obj.LastModified != nil {
+			sk.LastModified = *obj.LastModified
+		}
+		keys = append(keys, sk)
+	}
+	return keys, nil
+}
+
+// s3Backend adapts analyzeBucket to the Backend interface.
+type s3Backend struct {
+	signed            *s3.Client
+	anon              *s3.Client
+	probeWriteEnabled bool
+	listLimit         int // if > 0, list up to this many objects from readable buckets
+}
+
+func (b *s3Backend) Name() string { return "aws" }
+
+func (b *s3Backend) Probe(ctx context.Context, domain string) Result {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	result := analyzeBucket(ctx, b.signed, b.anon, domain, b.probeWriteEnabled)
+	result.provider = b.Name()
+
+	if b.listLimit > 0 && (result.canRead || result.authRead) {
+		client := b.anon
+		if !result.canRead {
+			client = b.signed
+		}
+		keys, err := listSampleKeys(ctx, client, domain, b.listLimit)
+		result.sampleKeys = keys
+		if err != nil {
+			result.err = err
+		}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rateWindowSize is the number of samples in the rolling window used to
+// compute probes/sec (see throughputTracker.sample). main samples every
+// 500ms, so 21 samples span 20 intervals, i.e. the last 10 seconds.
+const rateWindowSize = 21
+
+type rateSample struct {
+	at    time.Time
+	count int64
+}
+
+// rateWindow is a small fixed-size ring buffer of (timestamp, count)
+// samples used to compute a trailing rate without storing unbounded history.
+type rateWindow struct {
+	samples [rateWindowSize]rateSample
+	idx     int
+	filled  bool
+}
+
+func newRateWindow(at time.Time, count int64) *rateWindow {
+	w := &rateWindow{idx: 1}
+	w.samples[0] = rateSample{at: at, count: count}
+	return w
+}
+
+func (w *rateWindow) add(at time.Time, count int64) {
+	w.samples[w.idx] = rateSample{at: at, count: count}
+	w.idx = (w.idx + 1) % len(w.samples)
+	if w.idx == 0 {
+		w.filled = true
+	}
+}
+
+// oldest returns the earliest sample still in the window. Before the window
+// is full, that's slot 0 - the window effectively just grows from its first
+// sample until it reaches its full 10-second span.
+func (w *rateWindow) oldest() rateSample {
+	if !w.filled {
+		return w.samples[0]
+	}
+	return w.samples[w.idx]
+}
+
+// rate returns probes/sec between the oldest sample and (now, count).
+func (w *rateWindow) rate(now time.Time, count int64) float64 {
+	old := w.oldest()
+	dt := now.Sub(old.at).Seconds()
+	if dt <= 0 {
+		return 0
+	}
+	return float64(count-old.count) / dt
+}
+
+// tickMsg is sent to the TUI roughly twice a second with a fresh throughput
+// reading.
+type tickMsg struct {
+	completed     int64
+	rate          float64
+	elapsed       time.Duration
+	providerRates map[string]float64
+}
+
+// throughputTracker accumulates completed-probe counts, overall and per
+// provider, and derives rolling rates from them. It's safe for concurrent
+// use: workers call record as results come in, while a single ticker
+// goroutine calls sample to build tickMsgs for the UI.
+type throughputTracker struct {
+	mu               sync.Mutex
+	start            time.Time
+	total            int64
+	byProvider       map[string]int64
+	window           *rateWindow
+	byProviderWindow map[string]*rateWindow
+}
+
+func newThroughputTracker() *throughputTracker {
+	now := time.Now()
+	return &throughputTracker{
+		start:            now,
+		byProvider:       make(map[string]int64),
+		window:           newRateWindow(now, 0),
+		byProviderWindow: make(map[string]*rateWindow),
+	}
+}
+
+func (t *throughputTracker) record(provider string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total++
+	t.byProvider[provider]++
+}
+
+func (t *throughputTracker) sample(now time.Time) tickMsg {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.window.add(now, t.total)
+	msg := tickMsg{
+		completed:     t.total,
+		rate:          t.window.rate(now, t.total),
+		elapsed:       now.Sub(t.start),
+		providerRates: make(map[string]float64, len(t.byProvider)),
+	}
+
+	for provider, count := range t.byProvider {
+		pw := t.byProviderWindow[provider]
+		if pw == nil {
+			pw = newRateWindow(now, count)
+			t.byProviderWindow[provider] = pw
+		}
+		pw.add(now, count)
+		msg.providerRates[provider] = pw.rate(now, count)
+	}
+	return msg
+}
+
+// formatRate renders probes/sec compactly, e.g. "1.2k/s" or "34/s".
+func formatRate(rate float64) string {
+	if rate >= 1000 {
+		return fmt.Sprintf("%.1fk/s", rate/1000)
+	}
+	return fmt.Sprintf("%.0f/s", rate)
+}
+
+// formatDuration renders a duration compactly, e.g. "3m12s" or "1h02m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	case m > 0:
+		return fmt.Sprintf("%dm%02ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
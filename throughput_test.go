@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateWindowFillAndWraparound(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	w := newRateWindow(base, 0)
+
+	// Fill the window: rateWindowSize-1 more samples, one per second, count
+	// growing by 10/sec, so the window holds exactly rateWindowSize samples.
+	for i := 1; i < rateWindowSize; i++ {
+		w.add(base.Add(time.Duration(i)*time.Second), int64(i*10))
+	}
+	if !w.filled {
+		t.Fatalf("window should be filled after %d samples", rateWindowSize)
+	}
+	if got := w.oldest(); got.at != base || got.count != 0 {
+		t.Errorf("oldest() = %+v, want the initial sample at %v with count 0", got, base)
+	}
+
+	now := base.Add(time.Duration(rateWindowSize) * time.Second)
+	count := int64(rateWindowSize * 10)
+	if rate := w.rate(now, count); rate != 10 {
+		t.Errorf("rate() = %v, want 10 (constant 10/sec growth)", rate)
+	}
+
+	// One more sample past the full window wraps the ring buffer, evicting
+	// the original oldest sample (base, count 0) for the one after it.
+	w.add(now, count)
+	want := rateSample{at: base.Add(1 * time.Second), count: 10}
+	if got := w.oldest(); got != want {
+		t.Errorf("oldest() after wraparound = %+v, want %+v", got, want)
+	}
+}
+
+func TestRateWindowZeroElapsed(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	w := newRateWindow(now, 5)
+	if rate := w.rate(now, 5); rate != 0 {
+		t.Errorf("rate() with zero elapsed time = %v, want 0", rate)
+	}
+}